@@ -0,0 +1,105 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// AuthConfig describes how a source should authenticate its requests.
+type AuthConfig struct {
+	Type     string `yaml:"type" json:"type"` // basic, bearer
+	Username string `yaml:"username" json:"username"`
+	Password string `yaml:"password" json:"password"`
+	Token    string `yaml:"token" json:"token"`
+}
+
+// SourceConfig is one feed to fetch.
+type SourceConfig struct {
+	URL     string            `yaml:"url" json:"url"`
+	Headers map[string]string `yaml:"headers" json:"headers"`
+	Auth    *AuthConfig       `yaml:"auth" json:"auth"`
+}
+
+// RuleConfig is one conversion step in the pipeline. Field meanings
+// depend on Type: replace/regex-replace use Field/Search/Replace,
+// xpath uses Path/Replace (or Delete), strip-html uses Field,
+// prefix-title uses Prefix, rewrite-link-domain uses FromDomain/ToDomain.
+type RuleConfig struct {
+	Type       string `yaml:"type" json:"type"`
+	Field      string `yaml:"field" json:"field"`
+	Search     string `yaml:"search" json:"search"`
+	Replace    string `yaml:"replace" json:"replace"`
+	Path       string `yaml:"path" json:"path"`
+	Attr       string `yaml:"attr" json:"attr"`
+	Delete     bool   `yaml:"delete" json:"delete"`
+	Prefix     string `yaml:"prefix" json:"prefix"`
+	FromDomain string `yaml:"from_domain" json:"from_domain"`
+	ToDomain   string `yaml:"to_domain" json:"to_domain"`
+}
+
+// SinkConfig is one destination for the converted feed.
+type SinkConfig struct {
+	Type    string            `yaml:"type" json:"type"` // stdout, file, http
+	Path    string            `yaml:"path" json:"path"`
+	URL     string            `yaml:"url" json:"url"`
+	Method  string            `yaml:"method" json:"method"`
+	Headers map[string]string `yaml:"headers" json:"headers"`
+}
+
+// PipelineConfig is the top-level shape of a -config file.
+type PipelineConfig struct {
+	Sources []SourceConfig `yaml:"sources" json:"sources"`
+	Rules   []RuleConfig   `yaml:"rules" json:"rules"`
+	Sinks   []SinkConfig   `yaml:"sinks" json:"sinks"`
+}
+
+// LoadPipelineConfig reads and validates a pipeline config file. YAML
+// is assumed unless the file has a .json extension.
+func LoadPipelineConfig(path string) (*PipelineConfig, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %s", err.Error())
+	}
+
+	var cfg PipelineConfig
+	if strings.ToLower(filepath.Ext(path)) == ".json" {
+		err = json.Unmarshal(data, &cfg)
+	} else {
+		err = yaml.Unmarshal(data, &cfg)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config file: %s", err.Error())
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+func (c *PipelineConfig) Validate() error {
+	if len(c.Sources) == 0 {
+		return fmt.Errorf("config must declare at least one source")
+	}
+	for i, src := range c.Sources {
+		if src.URL == "" {
+			return fmt.Errorf("source %d is missing a url", i)
+		}
+	}
+	for i, rule := range c.Rules {
+		if rule.Type == "" {
+			return fmt.Errorf("rule %d is missing a type", i)
+		}
+	}
+	for i, sink := range c.Sinks {
+		if sink.Type == "" {
+			return fmt.Errorf("sink %d is missing a type", i)
+		}
+	}
+	return nil
+}
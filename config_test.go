@@ -0,0 +1,116 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadPipelineConfigYAML(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "rules.yaml")
+	contents := `
+sources:
+  - url: http://example.com/feed.xml
+rules:
+  - type: replace
+    field: title
+    search: foo
+    replace: bar
+sinks:
+  - type: stdout
+`
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadPipelineConfig(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(cfg.Sources) != 1 || cfg.Sources[0].URL != "http://example.com/feed.xml" {
+		t.Fatalf("unexpected sources: %+v", cfg.Sources)
+	}
+	if len(cfg.Rules) != 1 || cfg.Rules[0].Type != "replace" {
+		t.Fatalf("unexpected rules: %+v", cfg.Rules)
+	}
+}
+
+func TestLoadPipelineConfigJSON(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "rules.json")
+	contents := `{
+		"sources": [{"url": "http://example.com/feed.xml"}],
+		"sinks": [{"type": "stdout"}]
+	}`
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadPipelineConfig(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(cfg.Sources) != 1 {
+		t.Fatalf("unexpected sources: %+v", cfg.Sources)
+	}
+}
+
+func TestPipelineConfigValidate(t *testing.T) {
+	cases := []struct {
+		name    string
+		cfg     PipelineConfig
+		wantErr bool
+	}{
+		{
+			name:    "no sources",
+			cfg:     PipelineConfig{},
+			wantErr: true,
+		},
+		{
+			name: "source missing url",
+			cfg: PipelineConfig{
+				Sources: []SourceConfig{{}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "rule missing type",
+			cfg: PipelineConfig{
+				Sources: []SourceConfig{{URL: "http://x"}},
+				Rules:   []RuleConfig{{}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "sink missing type",
+			cfg: PipelineConfig{
+				Sources: []SourceConfig{{URL: "http://x"}},
+				Sinks:   []SinkConfig{{}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid minimal config",
+			cfg: PipelineConfig{
+				Sources: []SourceConfig{{URL: "http://x"}},
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.cfg.Validate()
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadPipelineConfigMissingFile(t *testing.T) {
+	if _, err := LoadPipelineConfig("/nonexistent/rules.yaml"); err == nil {
+		t.Fatal("expected an error for a missing config file")
+	}
+}
@@ -0,0 +1,97 @@
+package main
+
+import "strings"
+
+// FeedField names one of the normalized fields on Item (or the
+// equivalent channel-level field on Feed) that a Converter can target.
+type FeedField string
+
+const (
+	FieldTitle       FeedField = "title"
+	FieldDescription FeedField = "description"
+	FieldLink        FeedField = "link"
+	FieldContent     FeedField = "content"
+	FieldAuthor      FeedField = "author"
+)
+
+// Converter interface
+type Converter interface {
+	Convert(*[]*Feed) []*Feed
+}
+
+// FieldReplaceConverter class implements Converter. It does a plain
+// strings.Replace, but only on the targeted field of each item (and the
+// matching channel-level field), instead of the raw document body.
+type FieldReplaceConverter struct {
+	field   FeedField
+	search  string
+	replace string
+}
+
+func (rep *FieldReplaceConverter) Convert(feeds *[]*Feed) []*Feed {
+	for _, feed := range *feeds {
+		rep.replaceChannel(feed)
+		for _, item := range feed.Items {
+			rep.replaceItem(item)
+		}
+	}
+
+	return *feeds
+}
+
+func (rep *FieldReplaceConverter) replaceChannel(feed *Feed) {
+	switch rep.field {
+	case FieldTitle:
+		feed.Title = strings.Replace(feed.Title, rep.search, rep.replace, -1)
+	case FieldDescription:
+		feed.Description = strings.Replace(feed.Description, rep.search, rep.replace, -1)
+	case FieldLink:
+		feed.Link = strings.Replace(feed.Link, rep.search, rep.replace, -1)
+	}
+}
+
+func (rep *FieldReplaceConverter) replaceItem(item *Item) {
+	switch rep.field {
+	case FieldTitle:
+		item.Title = strings.Replace(item.Title, rep.search, rep.replace, -1)
+	case FieldDescription:
+		item.Description = strings.Replace(item.Description, rep.search, rep.replace, -1)
+	case FieldLink:
+		item.Link = strings.Replace(item.Link, rep.search, rep.replace, -1)
+	case FieldContent:
+		item.Content = strings.Replace(item.Content, rep.search, rep.replace, -1)
+	case FieldAuthor:
+		item.Author = strings.Replace(item.Author, rep.search, rep.replace, -1)
+	}
+}
+
+func NewFieldReplaceConverter(field FeedField, search string, replace string) Converter {
+	return &FieldReplaceConverter{
+		field:   field,
+		search:  search,
+		replace: replace,
+	}
+}
+
+// CompositeConverter class implements Converter. It chains other
+// Converters together, running each in order on the previous one's
+// output, so separate conversions (e.g. a replace followed by a merge)
+// can be composed from the CLI.
+type CompositeConverter struct {
+	converters []Converter
+}
+
+func (c *CompositeConverter) Convert(feeds *[]*Feed) []*Feed {
+	cur := *feeds
+	for _, converter := range c.converters {
+		cur = converter.Convert(&cur)
+	}
+
+	return cur
+}
+
+func NewCompositeConverter(converters ...Converter) Converter {
+	return &CompositeConverter{
+		converters: converters,
+	}
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+)
+
+var unsafeFilenameChars = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
+// DirPrinter class implements Printer. It splits a (possibly merged)
+// feed back out into one file per item, which pairs naturally with
+// MergeConverter.
+type DirPrinter struct {
+	dir string
+}
+
+func NewDirPrinter(dir string) Printer {
+	return &DirPrinter{dir: dir}
+}
+
+func (d *DirPrinter) Print(rss *[]string) {
+	if err := os.MkdirAll(d.dir, 0755); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to create output dir %s: %s\n", d.dir, err.Error())
+		exitCode = 8
+		return
+	}
+
+	parser := NewFeedParser()
+	serializer := NewFeedSerializer()
+
+	index := 0
+	for _, raw := range *rss {
+		feed, err := parser.Parse(raw)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to split feed: %s\n", err.Error())
+			exitCode = 8
+			continue
+		}
+
+		for _, item := range feed.Items {
+			index++
+			single := &Feed{
+				Format:      feed.Format,
+				Title:       feed.Title,
+				Link:        feed.Link,
+				Description: feed.Description,
+				Items:       []*Item{item},
+			}
+			out, err := serializer.Serialize(single)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to serialize item %d: %s\n", index, err.Error())
+				exitCode = 8
+				continue
+			}
+
+			path := filepath.Join(d.dir, itemFileName(index, item))
+			if err := ioutil.WriteFile(path, []byte(out), 0644); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to write %s: %s\n", path, err.Error())
+				exitCode = 8
+			}
+		}
+	}
+}
+
+func itemFileName(index int, item *Item) string {
+	key := item.GUID
+	if key == "" {
+		key = item.Link
+	}
+	if key == "" {
+		return fmt.Sprintf("%04d.xml", index)
+	}
+	return fmt.Sprintf("%04d-%s.xml", index, unsafeFilenameChars.ReplaceAllString(key, "_"))
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDirPrinterSplitsFeedIntoOneFilePerItem(t *testing.T) {
+	dir := t.TempDir()
+	printer := NewDirPrinter(filepath.Join(dir, "out"))
+
+	raw := `<?xml version="1.0"?>
+<rss version="2.0"><channel>
+<title>T</title><link>http://x</link><description>D</description>
+<item><title>I1</title><guid>g1</guid></item>
+<item><title>I2</title><link>http://x/2</link></item>
+</channel></rss>`
+
+	rssBodies := []string{raw}
+	printer.Print(&rssBodies)
+
+	entries, err := os.ReadDir(filepath.Join(dir, "out"))
+	if err != nil {
+		t.Fatalf("ReadDir: %s", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("got %d files, want 2: %v", len(entries), entries)
+	}
+
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	foundGUID := false
+	for _, n := range names {
+		if strings.Contains(n, "g1") {
+			foundGUID = true
+		}
+	}
+	if !foundGUID {
+		t.Fatalf("expected a filename derived from item GUID, got %v", names)
+	}
+}
+
+func TestItemFileNameSanitizesUnsafeChars(t *testing.T) {
+	name := itemFileName(3, &Item{GUID: "http://x/y?z=1"})
+	if strings.ContainsAny(name, "/:?") {
+		t.Fatalf("itemFileName produced an unsafe path: %q", name)
+	}
+	if !strings.HasPrefix(name, "0003-") {
+		t.Fatalf("itemFileName = %q, want a 0003- prefix", name)
+	}
+}
+
+func TestItemFileNameFallsBackToIndex(t *testing.T) {
+	name := itemFileName(7, &Item{})
+	if name != "0007.xml" {
+		t.Fatalf("itemFileName = %q, want %q", name, "0007.xml")
+	}
+}
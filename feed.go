@@ -0,0 +1,440 @@
+package main
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+)
+
+// FeedFormat identifies which wire format a Feed was parsed from (or
+// should be serialized as).
+type FeedFormat int
+
+const (
+	FormatRSS FeedFormat = iota
+	FormatAtom
+)
+
+// Item is a single entry in a feed, normalized across RSS and Atom.
+type Item struct {
+	Title       string
+	Link        string
+	Description string
+	Content     string // content:encoded (RSS) or <content> (Atom)
+	Author      string
+	GUID        string
+	PubDate     string
+	Extra       []RawXML // unmodeled child elements (category, enclosure, ...), preserved verbatim
+
+	// contentDerived marks an Atom entry that had no <content> of its
+	// own, so Content was filled in from Summary as a convenience for
+	// Converters targeting FieldContent. serializeAtom re-derives it
+	// from the (possibly since-edited) Description instead of
+	// re-emitting this stale copy; see parseAtom.
+	contentDerived bool
+}
+
+// RawXML is a feed element this package doesn't model in Item or Feed
+// (e.g. <category>, <enclosure>, custom namespaces). Converters that
+// don't touch these fields round-trip them unchanged instead of
+// silently dropping them.
+type RawXML struct {
+	XMLName xml.Name
+	Attrs   []xml.Attr `xml:",any,attr"`
+	Content string     `xml:",innerxml"`
+}
+
+// Feed is the in-memory representation shared by every Converter,
+// regardless of which wire format it was parsed from.
+type Feed struct {
+	Format      FeedFormat
+	Title       string
+	Link        string
+	Description string
+	Items       []*Item
+	Extra       []RawXML // unmodeled channel elements (language, image, atom:link, ...), preserved verbatim
+}
+
+// FeedParser turns a raw RSS or Atom document into a Feed.
+type FeedParser interface {
+	Parse(raw string) (*Feed, error)
+}
+
+// FeedSerializer turns a Feed back into a raw RSS or Atom document.
+type FeedSerializer interface {
+	Serialize(feed *Feed) (string, error)
+}
+
+// AutoFeedParser detects RSS 2.0 vs Atom by looking at the root element
+// and dispatches to the matching parser.
+type AutoFeedParser struct{}
+
+func NewFeedParser() FeedParser {
+	return &AutoFeedParser{}
+}
+
+func (p *AutoFeedParser) Parse(raw string) (*Feed, error) {
+	root, err := rootElement(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to detect feed format: %s", err.Error())
+	}
+
+	switch root {
+	case "rss":
+		return parseRSS(raw)
+	case "feed":
+		return parseAtom(raw)
+	default:
+		return nil, fmt.Errorf("unrecognized feed root element <%s>", root)
+	}
+}
+
+// rootElement returns the local name of the document's root element.
+func rootElement(raw string) (string, error) {
+	dec := xml.NewDecoder(bytes.NewReader([]byte(raw)))
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return "", err
+		}
+		if start, ok := tok.(xml.StartElement); ok {
+			return start.Name.Local, nil
+		}
+	}
+}
+
+// rssXML and atomXML mirror just enough of each spec to round-trip the
+// fields Converters care about.
+const atomNamespace = "http://www.w3.org/2005/Atom"
+
+// contentNamespace is the RSS 1.0 content module's namespace, used by
+// content:encoded.
+const contentNamespace = "http://purl.org/rss/1.0/modules/content/"
+
+type rssXML struct {
+	XMLName xml.Name      `xml:"rss"`
+	Version string        `xml:"version,attr"`
+	Channel rssChannelXML `xml:"channel"`
+}
+
+type rssChannelXML struct {
+	Title       string       `xml:"title"`
+	Link        string       `xml:"link"`
+	Description string       `xml:"description"`
+	Items       []rssItemXML `xml:"item"`
+	Extra       []RawXML     `xml:",any"`
+}
+
+// UnmarshalXML decodes Title/Link/Description only from elements with
+// no namespace of their own, so a namespaced lookalike -- most commonly
+// WordPress/Feedburner/Substack's <atom:link rel="self" .../> self
+// reference, which shares the local name "link" -- doesn't clobber the
+// real channel link the way a plain `xml:"link"` tag match would.
+// Everything it doesn't recognize (including those namespaced
+// lookalikes) is preserved verbatim in Extra instead of being dropped.
+func (c *rssChannelXML) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return err
+		}
+		if end, ok := tok.(xml.EndElement); ok {
+			if end.Name == start.Name {
+				return nil
+			}
+			continue
+		}
+		child, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		switch {
+		case child.Name.Local == "item":
+			var item rssItemXML
+			if err := d.DecodeElement(&item, &child); err != nil {
+				return err
+			}
+			c.Items = append(c.Items, item)
+		case child.Name.Space == "" && child.Name.Local == "title":
+			if err := d.DecodeElement(&c.Title, &child); err != nil {
+				return err
+			}
+		case child.Name.Space == "" && child.Name.Local == "link":
+			if err := d.DecodeElement(&c.Link, &child); err != nil {
+				return err
+			}
+		case child.Name.Space == "" && child.Name.Local == "description":
+			if err := d.DecodeElement(&c.Description, &child); err != nil {
+				return err
+			}
+		default:
+			var raw RawXML
+			if err := d.DecodeElement(&raw, &child); err != nil {
+				return err
+			}
+			c.Extra = append(c.Extra, raw)
+		}
+	}
+}
+
+type rssItemXML struct {
+	Title       string   `xml:"title,omitempty"`
+	Link        string   `xml:"link,omitempty"`
+	Description string   `xml:"description,omitempty"`
+	Content     string   `xml:"http://purl.org/rss/1.0/modules/content/ encoded,omitempty"`
+	Author      string   `xml:"author,omitempty"`
+	GUID        string   `xml:"guid,omitempty"`
+	PubDate     string   `xml:"pubDate,omitempty"`
+	Extra       []RawXML `xml:",any"`
+}
+
+// UnmarshalXML mirrors rssChannelXML's: Title/Link/Description only
+// match elements with no namespace, so item-level atom:link or similar
+// lookalikes land in Extra instead of clobbering the modeled fields.
+func (it *rssItemXML) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return err
+		}
+		if end, ok := tok.(xml.EndElement); ok {
+			if end.Name == start.Name {
+				return nil
+			}
+			continue
+		}
+		child, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		switch {
+		case child.Name.Space == "" && child.Name.Local == "title":
+			if err := d.DecodeElement(&it.Title, &child); err != nil {
+				return err
+			}
+		case child.Name.Space == "" && child.Name.Local == "link":
+			if err := d.DecodeElement(&it.Link, &child); err != nil {
+				return err
+			}
+		case child.Name.Space == "" && child.Name.Local == "description":
+			if err := d.DecodeElement(&it.Description, &child); err != nil {
+				return err
+			}
+		case child.Name.Space == contentNamespace && child.Name.Local == "encoded":
+			if err := d.DecodeElement(&it.Content, &child); err != nil {
+				return err
+			}
+		case child.Name.Space == "" && child.Name.Local == "author":
+			if err := d.DecodeElement(&it.Author, &child); err != nil {
+				return err
+			}
+		case child.Name.Space == "" && child.Name.Local == "guid":
+			if err := d.DecodeElement(&it.GUID, &child); err != nil {
+				return err
+			}
+		case child.Name.Space == "" && child.Name.Local == "pubDate":
+			if err := d.DecodeElement(&it.PubDate, &child); err != nil {
+				return err
+			}
+		default:
+			var raw RawXML
+			if err := d.DecodeElement(&raw, &child); err != nil {
+				return err
+			}
+			it.Extra = append(it.Extra, raw)
+		}
+	}
+}
+
+type atomLinkXML struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+}
+
+type atomXML struct {
+	XMLName  xml.Name       `xml:"feed"`
+	Xmlns    string         `xml:"xmlns,attr"`
+	Title    string         `xml:"title"`
+	Links    []atomLinkXML  `xml:"link"`
+	Subtitle string         `xml:"subtitle"`
+	Entries  []atomEntryXML `xml:"entry"`
+}
+
+// atomAuthorXML is a pointer field on atomEntryXML so an entry with no
+// author omits the element entirely instead of emitting an empty
+// <author><name></name></author>.
+type atomAuthorXML struct {
+	Name string `xml:"name"`
+}
+
+type atomEntryXML struct {
+	Title   string         `xml:"title,omitempty"`
+	Links   []atomLinkXML  `xml:"link"`
+	Summary string         `xml:"summary,omitempty"`
+	Content string         `xml:"content,omitempty"`
+	Author  *atomAuthorXML `xml:"author"`
+	ID      string         `xml:"id,omitempty"`
+	Updated string         `xml:"updated,omitempty"`
+	Extra   []RawXML       `xml:",any"`
+}
+
+func parseRSS(raw string) (*Feed, error) {
+	var x rssXML
+	if err := xml.Unmarshal([]byte(raw), &x); err != nil {
+		return nil, fmt.Errorf("failed to parse RSS: %s", err.Error())
+	}
+
+	feed := &Feed{
+		Format:      FormatRSS,
+		Title:       x.Channel.Title,
+		Link:        x.Channel.Link,
+		Description: x.Channel.Description,
+		Extra:       x.Channel.Extra,
+	}
+	for _, it := range x.Channel.Items {
+		feed.Items = append(feed.Items, &Item{
+			Title:       it.Title,
+			Link:        it.Link,
+			Description: it.Description,
+			Content:     it.Content,
+			Author:      it.Author,
+			GUID:        it.GUID,
+			PubDate:     it.PubDate,
+			Extra:       it.Extra,
+		})
+	}
+	return feed, nil
+}
+
+func parseAtom(raw string) (*Feed, error) {
+	var x atomXML
+	if err := xml.Unmarshal([]byte(raw), &x); err != nil {
+		return nil, fmt.Errorf("failed to parse Atom: %s", err.Error())
+	}
+
+	feed := &Feed{
+		Format:      FormatAtom,
+		Title:       x.Title,
+		Link:        atomLink(x.Links),
+		Description: x.Subtitle,
+	}
+	for _, e := range x.Entries {
+		content := e.Content
+		derived := false
+		if content == "" {
+			content = e.Summary
+			derived = true
+		}
+		var author string
+		if e.Author != nil {
+			author = e.Author.Name
+		}
+		feed.Items = append(feed.Items, &Item{
+			Title:          e.Title,
+			Link:           atomLink(e.Links),
+			Description:    e.Summary,
+			Content:        content,
+			Author:         author,
+			GUID:           e.ID,
+			PubDate:        e.Updated,
+			Extra:          e.Extra,
+			contentDerived: derived,
+		})
+	}
+	return feed, nil
+}
+
+// atomLink prefers the alternate link, falling back to the first one.
+func atomLink(links []atomLinkXML) string {
+	for _, l := range links {
+		if l.Rel == "" || l.Rel == "alternate" {
+			return l.Href
+		}
+	}
+	if len(links) > 0 {
+		return links[0].Href
+	}
+	return ""
+}
+
+// XMLFeedSerializer re-emits a Feed as RSS or Atom depending on its
+// Format, using encoding/xml.
+type XMLFeedSerializer struct{}
+
+func NewFeedSerializer() FeedSerializer {
+	return &XMLFeedSerializer{}
+}
+
+func (s *XMLFeedSerializer) Serialize(feed *Feed) (string, error) {
+	switch feed.Format {
+	case FormatAtom:
+		return serializeAtom(feed)
+	default:
+		return serializeRSS(feed)
+	}
+}
+
+func serializeRSS(feed *Feed) (string, error) {
+	var x rssXML
+	x.Version = "2.0"
+	x.Channel.Title = feed.Title
+	x.Channel.Link = feed.Link
+	x.Channel.Description = feed.Description
+	x.Channel.Extra = feed.Extra
+	for _, it := range feed.Items {
+		x.Channel.Items = append(x.Channel.Items, rssItemXML{
+			Title:       it.Title,
+			Link:        it.Link,
+			Description: it.Description,
+			Content:     it.Content,
+			Author:      it.Author,
+			GUID:        it.GUID,
+			PubDate:     it.PubDate,
+			Extra:       it.Extra,
+		})
+	}
+
+	out, err := xml.MarshalIndent(x, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to serialize RSS: %s", err.Error())
+	}
+	return xml.Header + string(out), nil
+}
+
+func serializeAtom(feed *Feed) (string, error) {
+	var x atomXML
+	x.Xmlns = atomNamespace
+	x.Title = feed.Title
+	x.Links = []atomLinkXML{{Href: feed.Link, Rel: "alternate"}}
+	x.Subtitle = feed.Description
+	for _, it := range feed.Items {
+		content := it.Content
+		if it.contentDerived {
+			// Content was only ever a fallback copy of Summary at parse
+			// time; re-derive it from the current Description instead
+			// of re-emitting a possibly stale, pre-conversion value.
+			content = it.Description
+		}
+		entry := atomEntryXML{
+			Title:   it.Title,
+			Links:   []atomLinkXML{{Href: it.Link, Rel: "alternate"}},
+			Summary: it.Description,
+			Content: content,
+			ID:      it.GUID,
+			Updated: it.PubDate,
+			Extra:   it.Extra,
+		}
+		if it.Author != "" {
+			entry.Author = &atomAuthorXML{Name: it.Author}
+		}
+		x.Entries = append(x.Entries, entry)
+	}
+
+	out, err := xml.MarshalIndent(x, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to serialize Atom: %s", err.Error())
+	}
+	return xml.Header + string(out), nil
+}
@@ -0,0 +1,200 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseRSSBasic(t *testing.T) {
+	raw := `<?xml version="1.0"?>
+<rss version="2.0"><channel>
+<title>T</title><link>http://x</link><description>D</description>
+<item>
+<title>I1</title><link>http://x/1</link><description>desc</description>
+<guid>g1</guid><pubDate>Mon, 01 Jan 2024 00:00:00 +0000</pubDate>
+</item>
+</channel></rss>`
+
+	feed, err := NewFeedParser().Parse(raw)
+	if err != nil {
+		t.Fatalf("Parse: %s", err)
+	}
+	if feed.Format != FormatRSS {
+		t.Fatalf("Format = %v, want FormatRSS", feed.Format)
+	}
+	if feed.Title != "T" || feed.Link != "http://x" || feed.Description != "D" {
+		t.Fatalf("unexpected channel: %+v", feed)
+	}
+	if len(feed.Items) != 1 || feed.Items[0].Title != "I1" || feed.Items[0].GUID != "g1" {
+		t.Fatalf("unexpected items: %+v", feed.Items)
+	}
+}
+
+func TestParseRSSIgnoresNamespacedLinkLookalike(t *testing.T) {
+	raw := `<?xml version="1.0"?>
+<rss version="2.0"><channel>
+<title>T</title>
+<link>http://real-link</link>
+<atom:link xmlns:atom="http://www.w3.org/2005/Atom" href="http://self" rel="self"/>
+<description>D</description>
+<item>
+<title>I1</title>
+<link>http://item-link</link>
+<atom:link xmlns:atom="http://www.w3.org/2005/Atom" href="http://item-self" rel="self"/>
+<description>desc</description>
+</item>
+</channel></rss>`
+
+	feed, err := NewFeedParser().Parse(raw)
+	if err != nil {
+		t.Fatalf("Parse: %s", err)
+	}
+	if feed.Link != "http://real-link" {
+		t.Fatalf("channel Link = %q, want %q (clobbered by atom:link lookalike)", feed.Link, "http://real-link")
+	}
+	if feed.Items[0].Link != "http://item-link" {
+		t.Fatalf("item Link = %q, want %q (clobbered by atom:link lookalike)", feed.Items[0].Link, "http://item-link")
+	}
+}
+
+func TestParseRSSPreservesUnmodeledChannelElements(t *testing.T) {
+	raw := `<?xml version="1.0"?>
+<rss version="2.0"><channel>
+<title>T</title><link>http://x</link><description>D</description>
+<language>en-us</language>
+<ttl>60</ttl>
+<item><title>I1</title></item>
+</channel></rss>`
+
+	feed, err := NewFeedParser().Parse(raw)
+	if err != nil {
+		t.Fatalf("Parse: %s", err)
+	}
+	names := make(map[string]bool)
+	for _, e := range feed.Extra {
+		names[e.XMLName.Local] = true
+	}
+	if !names["language"] || !names["ttl"] {
+		t.Fatalf("expected language and ttl in Feed.Extra, got %+v", feed.Extra)
+	}
+}
+
+func TestSerializeRSSOmitsEmptyFields(t *testing.T) {
+	feed := &Feed{
+		Format: FormatRSS,
+		Title:  "T",
+		Items: []*Item{
+			{Title: "I1", Link: "http://x/1", Description: "desc"},
+		},
+	}
+
+	out, err := NewFeedSerializer().Serialize(feed)
+	if err != nil {
+		t.Fatalf("Serialize: %s", err)
+	}
+	for _, tag := range []string{"<author>", "<guid>", "<pubDate>", "encoded"} {
+		if strings.Contains(out, tag) {
+			t.Fatalf("expected no %q in output for an empty field, got:\n%s", tag, out)
+		}
+	}
+}
+
+func TestRSSRoundTripPreservesExtraItemElements(t *testing.T) {
+	raw := `<?xml version="1.0"?>
+<rss version="2.0"><channel>
+<title>T</title><link>http://x</link><description>D</description>
+<item>
+<title>I1</title><link>http://x/1</link><description>desc</description>
+<category>Tech</category>
+<enclosure url="http://x/a.mp3" type="audio/mpeg"/>
+</item>
+</channel></rss>`
+
+	feed, err := NewFeedParser().Parse(raw)
+	if err != nil {
+		t.Fatalf("Parse: %s", err)
+	}
+	out, err := NewFeedSerializer().Serialize(feed)
+	if err != nil {
+		t.Fatalf("Serialize: %s", err)
+	}
+	if !strings.Contains(out, "<category>Tech</category>") {
+		t.Fatalf("expected <category> to round-trip, got:\n%s", out)
+	}
+	if !strings.Contains(out, `url="http://x/a.mp3"`) {
+		t.Fatalf("expected <enclosure> to round-trip, got:\n%s", out)
+	}
+}
+
+func TestParseAtomBasic(t *testing.T) {
+	raw := `<?xml version="1.0"?>
+<feed xmlns="http://www.w3.org/2005/Atom">
+<title>T</title><link href="http://x" rel="alternate"/><subtitle>D</subtitle>
+<entry>
+<title>E1</title><link href="http://x/1" rel="alternate"/>
+<summary>hello world</summary>
+<id>g1</id><updated>2024-01-01T00:00:00Z</updated>
+</entry>
+</feed>`
+
+	feed, err := NewFeedParser().Parse(raw)
+	if err != nil {
+		t.Fatalf("Parse: %s", err)
+	}
+	if feed.Format != FormatAtom {
+		t.Fatalf("Format = %v, want FormatAtom", feed.Format)
+	}
+	if feed.Items[0].Content != "hello world" {
+		t.Fatalf("Content = %q, want it derived from Summary", feed.Items[0].Content)
+	}
+}
+
+func TestSerializeAtomDerivedContentStaysInSyncWithDescription(t *testing.T) {
+	raw := `<?xml version="1.0"?>
+<feed xmlns="http://www.w3.org/2005/Atom">
+<title>T</title><link href="http://x" rel="alternate"/><subtitle>D</subtitle>
+<entry>
+<title>E1</title><link href="http://x/1" rel="alternate"/>
+<summary>hello world</summary>
+<id>g1</id><updated>2024-01-01T00:00:00Z</updated>
+</entry>
+</feed>`
+
+	feed, err := NewFeedParser().Parse(raw)
+	if err != nil {
+		t.Fatalf("Parse: %s", err)
+	}
+	// Simulate a Converter editing only Description, the way
+	// -convert-field=description does.
+	feed.Items[0].Description = "HI world"
+
+	out, err := NewFeedSerializer().Serialize(feed)
+	if err != nil {
+		t.Fatalf("Serialize: %s", err)
+	}
+	if !strings.Contains(out, "<summary>HI world</summary>") {
+		t.Fatalf("expected updated summary, got:\n%s", out)
+	}
+	if strings.Contains(out, "hello world") {
+		t.Fatalf("expected no stale pre-conversion content, got:\n%s", out)
+	}
+	if !strings.Contains(out, "<content>HI world</content>") {
+		t.Fatalf("expected derived content to track the updated description, got:\n%s", out)
+	}
+}
+
+func TestSerializeAtomOmitsEmptyAuthor(t *testing.T) {
+	feed := &Feed{
+		Format: FormatAtom,
+		Title:  "T",
+		Items:  []*Item{{Title: "E1", Description: "desc"}},
+	}
+
+	out, err := NewFeedSerializer().Serialize(feed)
+	if err != nil {
+		t.Fatalf("Serialize: %s", err)
+	}
+	if strings.Contains(out, "<author>") {
+		t.Fatalf("expected no <author> element for an entry with no author, got:\n%s", out)
+	}
+}
@@ -2,12 +2,17 @@ package main
 
 import (
 	"bufio"
+	"context"
 	"flag"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"os"
+	"os/signal"
 	"strings"
+	"sync"
+	"testing"
+	"time"
 )
 
 // To accept multiple option string variables
@@ -24,22 +29,42 @@ func (s *strslice) Set(v string) error {
 
 // Variables for CLI options for input
 var (
-	urls     strslice     //multiple input with -url=xxx -url=yyy
-	sword    string       //search word to be replaced with, -convert-search-word=xxx
-	rword    string       //replace word, -convert-replace-word=yyy
-	outfile  string       //output file, -out-file=xxx. If this is not specified, Output is stdout.
-	exitCode int      = 0 //total CLI exitCode
+	urls         strslice          //multiple input with -url=xxx -url=yyy. Also accepts file://glob and "-" for stdin.
+	files        strslice          //multiple local file/glob inputs, -file=feeds/*.xml -file=yyy
+	cfield       string            //field to target, -convert-field=xxx. One of title, description, link, content, author.
+	sword        string            //search word to be replaced with, -convert-search-word=xxx
+	rword        string            //replace word, -convert-replace-word=yyy
+	outfile      string            //output file, -out-file=xxx. If this is not specified, Output is stdout.
+	concurrency  int               //max in-flight fetches, -concurrency=N
+	fetchTimeout time.Duration     //per-request timeout, -timeout=30s
+	merge        bool              //combine every -url feed into one, -merge
+	mergeTitle   string            //synthesized channel title, -merge-title=xxx
+	mergeLink    string            //synthesized channel link, -merge-link=xxx
+	mergeDesc    string            //synthesized channel description, -merge-description=xxx
+	maxItems     int               //cap on merged item count, -max-items=N
+	cregex       string            //regexp search pattern, -convert-regex=xxx
+	cregexRepl   string            //regexp replacement (may use $1, $2, ...), -convert-regex-replace=yyy
+	xpathPath    string            //XPath expression to target, -xpath=//item/description
+	xpathRepl    string            //replacement text, -xpath-replace=yyy
+	xpathAttr    string            //attribute to rewrite instead of text, -xpath-attr=href
+	xpathDelete  bool              //delete matching nodes instead of rewriting them, -xpath-delete
+	cfgFile      string            //pipeline config file, -config=rules.yaml
+	exitCode     int           = 0 //total CLI exitCode
+	exitCodeMu   sync.Mutex        //guards exitCode writes from concurrent fetch workers
 )
 
+// setExitCode records a non-zero exitCode, safe to call from concurrent
+// goroutines (unlike a plain assignment to exitCode).
+func setExitCode(code int) {
+	exitCodeMu.Lock()
+	exitCode = code
+	exitCodeMu.Unlock()
+}
+
 // Interfaces
 // Loader interface
 type Loader interface {
-	Load() ([]string, error)
-}
-
-// Converter interface
-type Converter interface {
-	Convert(*[]string) []string
+	Load(ctx context.Context) ([]string, error)
 }
 
 // Printer interface
@@ -47,61 +72,147 @@ type Printer interface {
 	Print(*[]string)
 }
 
-// URLLoader class implements Loader
+// maxFetchRetries bounds the exponential backoff retries URLLoader
+// applies on top of 5xx responses or network errors.
+const maxFetchRetries = 3
+
+// URLLoader class implements Loader. Fetches run concurrently over a
+// bounded worker pool, with failures isolated per URL so one bad feed
+// doesn't abort the rest of the batch.
 type URLLoader struct {
-	urls *[]string
+	urls        *[]string
+	concurrency int
+	client      *http.Client
 }
 
-func (u *URLLoader) Load() ([]string, error) {
-	var bodies []string = make([]string, 0)
-	var resp *http.Response
-	var err error
-	var body []byte
-	for _, url := range *u.urls {
-		resp, err = http.Get(url)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Failed to do http request: %s", err.Error())
-			exitCode = 1
-			return bodies, err
-		}
-		body, err = ioutil.ReadAll(resp.Body)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Failed to read from http body: %s", err.Error())
-			exitCode = 2
-			return bodies, err
+func (u *URLLoader) Load(ctx context.Context) ([]string, error) {
+	urls := *u.urls
+	bodies := fetchConcurrently(ctx, u.concurrency, len(urls),
+		func(ctx context.Context, i int) (string, error) {
+			return u.fetchWithRetry(ctx, urls[i])
+		},
+		func(i int, err error) {
+			fmt.Fprintf(os.Stderr, "Failed to fetch %s: %s\n", urls[i], err.Error())
+			setExitCode(1)
+		},
+	)
+	return bodies, ctx.Err()
+}
+
+// fetchConcurrently runs n fetch jobs over a bounded worker pool,
+// isolating failures per job (reported via onError) so one bad source
+// doesn't abort the rest of the batch, and returns only the bodies that
+// fetched successfully -- a failed job leaves no placeholder for a
+// later ParseRSS to choke on. Shared by URLLoader and ConfigLoader so
+// -url and -config fetches get the same resilience.
+func fetchConcurrently(ctx context.Context, concurrency int, n int, fetch func(ctx context.Context, i int) (string, error), onError func(i int, err error)) []string {
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	bodies := make([]string, n)
+	fetched := make([]bool, n)
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				body, err := fetch(ctx, i)
+				if err != nil {
+					onError(i, err)
+					continue
+				}
+				bodies[i] = body
+				fetched[i] = true
+			}
+		}()
+	}
+
+sendLoop:
+	for i := 0; i < n; i++ {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			break sendLoop
 		}
-		bodies = append(bodies, string(body))
 	}
-	defer resp.Body.Close()
+	close(jobs)
+	wg.Wait()
 
-	return bodies, nil
+	ok := bodies[:0]
+	for i, body := range bodies {
+		if fetched[i] {
+			ok = append(ok, body)
+		}
+	}
+	return ok
 }
 
-func NewUrlLoader(urls *[]string) Loader {
-	return &URLLoader{
-		urls: urls,
+// fetchWithBackoff retries attempt on network errors or 5xx responses
+// using exponential backoff, bailing out early if ctx is cancelled.
+// Shared by URLLoader and ConfigLoader.
+func fetchWithBackoff(ctx context.Context, attempt func(ctx context.Context) (string, int, error)) (string, error) {
+	backoff := 500 * time.Millisecond
+	var lastErr error
+	for try := 0; try <= maxFetchRetries; try++ {
+		body, status, err := attempt(ctx)
+		if err == nil && status < 500 {
+			return body, nil
+		}
+		if err == nil {
+			lastErr = fmt.Errorf("server returned %d", status)
+		} else {
+			lastErr = err
+		}
+		if try == maxFetchRetries {
+			break
+		}
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+		backoff *= 2
 	}
+	return "", lastErr
 }
 
-// ReplaceConverter class implements Converter
-type ReplaceConverter struct {
-	search  string
-	replace string
+// fetchWithRetry retries on network errors or 5xx responses using
+// exponential backoff, bailing out early if ctx is cancelled.
+func (u *URLLoader) fetchWithRetry(ctx context.Context, url string) (string, error) {
+	return fetchWithBackoff(ctx, func(ctx context.Context) (string, int, error) {
+		return u.fetchOnce(ctx, url)
+	})
 }
 
-func (rep *ReplaceConverter) Convert(rss *[]string) []string {
-	var res []string = make([]string, 0)
-	for _, r := range *rss {
-		res = append(res, strings.Replace(r, rep.search, rep.replace, -1))
+func (u *URLLoader) fetchOnce(ctx context.Context, url string) (string, int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", 0, err
 	}
+	resp, err := u.client.Do(req)
+	if err != nil {
+		return "", 0, err
+	}
+	defer resp.Body.Close()
 
-	return res
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", resp.StatusCode, err
+	}
+	return string(body), resp.StatusCode, nil
 }
 
-func NewReplaceConverter(search string, replace string) Converter {
-	return &ReplaceConverter{
-		search:  search,
-		replace: replace,
+func NewUrlLoader(urls *[]string, concurrency int, timeout time.Duration) Loader {
+	return &URLLoader{
+		urls:        urls,
+		concurrency: concurrency,
+		client: &http.Client{
+			Timeout:   timeout,
+			Transport: &http.Transport{},
+		},
 	}
 }
 
@@ -150,25 +261,72 @@ func NewFileOutputPrinter(outputfile string) Printer {
 	}
 }
 
+// BuildOutputPrinter picks a Printer based on -out-file's prefix: empty
+// goes to stdout, http(s):// POSTs to a webhook, dir:// splits into
+// per-item files, anything else is a plain file path.
+func BuildOutputPrinter(outfile string) Printer {
+	switch {
+	case outfile == "":
+		return NewOutputPrinter()
+	case strings.HasPrefix(outfile, "http://") || strings.HasPrefix(outfile, "https://"):
+		return NewHTTPPrinter(outfile, "", nil)
+	case strings.HasPrefix(outfile, "dir://"):
+		return NewDirPrinter(strings.TrimPrefix(outfile, "dir://"))
+	default:
+		return NewFileOutputPrinter(outfile)
+	}
+}
+
 // RSSDocument class
 type RSSDocument struct {
-	rawrss    []string
-	loader    Loader
-	converter Converter
-	printer   Printer
+	rawrss     []string
+	feeds      []*Feed
+	loader     Loader
+	parser     FeedParser
+	converter  Converter
+	serializer FeedSerializer
+	printer    Printer
 }
 
-func (rd *RSSDocument) LoadRSS() {
+func (rd *RSSDocument) LoadRSS(ctx context.Context) {
 	var err error
-	rd.rawrss, err = rd.loader.Load()
+	rd.rawrss, err = rd.loader.Load(ctx)
 	if err != nil {
 		fmt.Fprintln(os.Stderr, "Failed to load RSS")
 	}
 	return
 }
 
+func (rd *RSSDocument) ParseRSS() {
+	rd.feeds = make([]*Feed, 0, len(rd.rawrss))
+	for _, raw := range rd.rawrss {
+		feed, err := rd.parser.Parse(raw)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to parse feed: %s\n", err.Error())
+			exitCode = 4
+			continue
+		}
+		rd.feeds = append(rd.feeds, feed)
+	}
+	return
+}
+
 func (rd *RSSDocument) ConvertRSS() {
-	rd.rawrss = rd.converter.Convert(&rd.rawrss)
+	rd.feeds = rd.converter.Convert(&rd.feeds)
+	return
+}
+
+func (rd *RSSDocument) SerializeRSS() {
+	rd.rawrss = make([]string, 0, len(rd.feeds))
+	for _, feed := range rd.feeds {
+		raw, err := rd.serializer.Serialize(feed)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to serialize feed: %s\n", err.Error())
+			exitCode = 5
+			continue
+		}
+		rd.rawrss = append(rd.rawrss, raw)
+	}
 	return
 }
 
@@ -177,50 +335,101 @@ func (rd *RSSDocument) PrintRSS() {
 	return
 }
 
-func NewRSSDocument(urls *[]string, sword string, rword string, outfile string) *RSSDocument {
-	var printer Printer
-	if outfile == "" {
-		printer = NewOutputPrinter()
-	} else {
-		printer = NewFileOutputPrinter(outfile)
+func NewRSSDocument(urls *[]string, files *[]string, field string, sword string, rword string, outfile string, concurrency int, timeout time.Duration) *RSSDocument {
+	printer := BuildOutputPrinter(outfile)
+	loader := BuildLoader(*urls, *files, concurrency, timeout)
+
+	converters := []Converter{NewFieldReplaceConverter(FeedField(field), sword, rword)}
+	if xpathPath != "" {
+		xc, err := NewXPathConverter(xpathPath, xpathRepl, xpathAttr, xpathDelete)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Invalid -xpath expression: %s\n", err.Error())
+			os.Exit(1)
+		}
+		converters = append(converters, xc)
+	}
+	if cregex != "" {
+		rc, err := NewRegexConverter(FeedField(field), cregex, cregexRepl)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Invalid -convert-regex pattern: %s\n", err.Error())
+			os.Exit(1)
+		}
+		converters = append(converters, rc)
 	}
-	var loader Loader = NewUrlLoader(urls)
-	var converter Converter = NewReplaceConverter(sword, rword)
+	if merge {
+		converters = append(converters, NewMergeConverter(mergeTitle, mergeLink, mergeDesc, maxItems))
+	}
+	var converter Converter = NewCompositeConverter(converters...)
 
 	return &RSSDocument{
-		rawrss:    make([]string, 0),
-		loader:    loader,
-		converter: converter,
-		printer:   printer,
+		rawrss:     make([]string, 0),
+		loader:     loader,
+		parser:     NewFeedParser(),
+		converter:  converter,
+		serializer: NewFeedSerializer(),
+		printer:    printer,
 	}
 }
 
 // Initialize only once at execution
 func init() {
 	// Parse CLI flags
-	flag.Var(&urls, "url", "URL to input RSS")
+	flag.Var(&urls, "url", "URL to input RSS; also accepts file://glob or \"-\" for stdin")
+	flag.Var(&files, "file", "Local file or glob to input RSS, e.g. -file=feeds/*.xml")
+	flag.StringVar(&cfield, "convert-field", string(FieldDescription), "Feed field to convert: title, description, link, content, author")
 	flag.StringVar(&sword, "convert-search-word", "", "Word to be replaced with")
 	flag.StringVar(&rword, "convert-replace-word", "", "Word to replace with")
 	flag.StringVar(&outfile, "out-file", "", "Output file path")
+	flag.IntVar(&concurrency, "concurrency", 4, "Max number of URLs to fetch concurrently")
+	flag.DurationVar(&fetchTimeout, "timeout", 30*time.Second, "Per-request fetch timeout")
+	flag.BoolVar(&merge, "merge", false, "Merge every -url feed into a single output feed")
+	flag.StringVar(&mergeTitle, "merge-title", "", "Title for the merged channel")
+	flag.StringVar(&mergeLink, "merge-link", "", "Link for the merged channel")
+	flag.StringVar(&mergeDesc, "merge-description", "", "Description for the merged channel")
+	flag.IntVar(&maxItems, "max-items", 0, "Cap on the number of items in the merged feed (0 = unlimited)")
+	flag.StringVar(&cregex, "convert-regex", "", "Regexp pattern to match on -convert-field, applied in addition to -convert-search-word")
+	flag.StringVar(&cregexRepl, "convert-regex-replace", "", "Replacement for -convert-regex; may reference capture groups as $1, $2, ...")
+	flag.StringVar(&xpathPath, "xpath", "", "XPath expression to target, e.g. //item/description")
+	flag.StringVar(&xpathRepl, "xpath-replace", "", "Replacement text for nodes matched by -xpath")
+	flag.StringVar(&xpathAttr, "xpath-attr", "", "Rewrite this attribute instead of the matched node's text")
+	flag.BoolVar(&xpathDelete, "xpath-delete", false, "Delete nodes matched by -xpath instead of rewriting them")
+	flag.StringVar(&cfgFile, "config", "", "Pipeline config file (YAML or JSON); overrides the -url/-convert-* flags")
+
+	// `go test` runs with its own flags (-test.*) on os.Args, not ours;
+	// parsing them here would abort the whole test binary.
+	if testing.Testing() {
+		return
+	}
 	flag.Parse()
 
-	if len(urls) <= 0 {
-		fmt.Fprintf(os.Stderr, "Need to specify 1 -url option at least.")
+	if cfgFile == "" && len(urls) <= 0 && len(files) <= 0 {
+		fmt.Fprintf(os.Stderr, "Need to specify 1 -url or -file option at least.")
 		os.Exit(1)
 	}
 }
 
 func main() {
-	fmt.Println("This is rssconv!")
-	fmt.Printf("%v\n", urls)
-	fmt.Printf("%v\n", sword)
-	fmt.Printf("%v\n", rword)
-	fmt.Printf("%v\n", outfile)
-
-	var cnvurls []string = urls
-	var rssdoc *RSSDocument = NewRSSDocument(&cnvurls, sword, rword, outfile)
-	rssdoc.LoadRSS()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	var rssdoc *RSSDocument
+	if cfgFile != "" {
+		var err error
+		rssdoc, err = RunConfigPipeline(cfgFile, concurrency, fetchTimeout)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to build pipeline from %s: %s\n", cfgFile, err.Error())
+			os.Exit(1)
+		}
+	} else {
+		var cnvurls []string = urls
+		var cnvfiles []string = files
+		rssdoc = NewRSSDocument(&cnvurls, &cnvfiles, cfield, sword, rword, outfile, concurrency, fetchTimeout)
+	}
+
+	rssdoc.LoadRSS(ctx)
+	rssdoc.ParseRSS()
 	rssdoc.ConvertRSS()
+	rssdoc.SerializeRSS()
 	rssdoc.PrintRSS()
 
 	os.Exit(exitCode)
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"sort"
+	"time"
+)
+
+// dateLayouts are the PubDate/Updated formats MergeConverter knows how
+// to sort by. Items whose date doesn't match any of these sort last.
+var dateLayouts = []string{
+	time.RFC1123Z,
+	time.RFC1123,
+	time.RFC822Z,
+	time.RFC822,
+	time.RFC3339,
+}
+
+func parseItemDate(value string) (time.Time, bool) {
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// MergeConverter class implements Converter. It combines every input
+// Feed into a single synthesized one, deduplicating items by GUID (or
+// link when no GUID is present), sorting by publication date
+// descending, and optionally capping the result to maxItems.
+type MergeConverter struct {
+	title       string
+	link        string
+	description string
+	maxItems    int
+}
+
+func (m *MergeConverter) Convert(feeds *[]*Feed) []*Feed {
+	merged := &Feed{
+		Title:       m.title,
+		Link:        m.link,
+		Description: m.description,
+	}
+	if len(*feeds) > 0 {
+		merged.Format = (*feeds)[0].Format
+	}
+
+	seen := make(map[string]bool)
+	for _, feed := range *feeds {
+		for _, item := range feed.Items {
+			key := item.GUID
+			if key == "" {
+				key = item.Link
+			}
+			if key != "" && seen[key] {
+				continue
+			}
+			if key != "" {
+				seen[key] = true
+			}
+			merged.Items = append(merged.Items, item)
+		}
+	}
+
+	sort.SliceStable(merged.Items, func(i, j int) bool {
+		ti, oki := parseItemDate(merged.Items[i].PubDate)
+		tj, okj := parseItemDate(merged.Items[j].PubDate)
+		if !oki || !okj {
+			return oki && !okj
+		}
+		return ti.After(tj)
+	})
+
+	if m.maxItems > 0 && len(merged.Items) > m.maxItems {
+		merged.Items = merged.Items[:m.maxItems]
+	}
+
+	return []*Feed{merged}
+}
+
+func NewMergeConverter(title string, link string, description string, maxItems int) Converter {
+	return &MergeConverter{
+		title:       title,
+		link:        link,
+		description: description,
+		maxItems:    maxItems,
+	}
+}
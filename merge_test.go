@@ -0,0 +1,95 @@
+package main
+
+import "testing"
+
+func TestMergeConverterDedup(t *testing.T) {
+	cases := []struct {
+		name  string
+		feeds []*Feed
+		want  []string // expected Titles, in order
+	}{
+		{
+			name: "dedupes by GUID across feeds",
+			feeds: []*Feed{
+				{Items: []*Item{{Title: "A", GUID: "g1"}}},
+				{Items: []*Item{{Title: "A dup", GUID: "g1"}, {Title: "B", GUID: "g2"}}},
+			},
+			want: []string{"A", "B"},
+		},
+		{
+			name: "falls back to Link when GUID is empty",
+			feeds: []*Feed{
+				{Items: []*Item{{Title: "A", Link: "http://x/1"}}},
+				{Items: []*Item{{Title: "A dup", Link: "http://x/1"}}},
+			},
+			want: []string{"A"},
+		},
+		{
+			name: "keeps items with no GUID or Link",
+			feeds: []*Feed{
+				{Items: []*Item{{Title: "A"}, {Title: "B"}}},
+			},
+			want: []string{"A", "B"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mc := NewMergeConverter("", "", "", 0)
+			merged := mc.Convert(&tc.feeds)
+			if len(merged) != 1 {
+				t.Fatalf("expected a single merged feed, got %d", len(merged))
+			}
+			got := make([]string, len(merged[0].Items))
+			for i, it := range merged[0].Items {
+				got[i] = it.Title
+			}
+			if len(got) != len(tc.want) {
+				t.Fatalf("got titles %v, want %v", got, tc.want)
+			}
+			for i := range got {
+				if got[i] != tc.want[i] {
+					t.Errorf("got titles %v, want %v", got, tc.want)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestMergeConverterSortsByDateDescending(t *testing.T) {
+	feeds := []*Feed{
+		{Items: []*Item{
+			{Title: "older", GUID: "1", PubDate: "Mon, 01 Jan 2024 00:00:00 +0000"},
+			{Title: "newer", GUID: "2", PubDate: "Wed, 01 Jan 2025 00:00:00 +0000"},
+			{Title: "undated", GUID: "3"},
+		}},
+	}
+
+	mc := NewMergeConverter("", "", "", 0)
+	merged := mc.Convert(&feeds)
+
+	got := make([]string, len(merged[0].Items))
+	for i, it := range merged[0].Items {
+		got[i] = it.Title
+	}
+	want := []string{"newer", "older", "undated"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("got order %v, want %v", got, want)
+		}
+	}
+}
+
+func TestMergeConverterMaxItems(t *testing.T) {
+	feeds := []*Feed{
+		{Items: []*Item{{Title: "A", GUID: "1"}, {Title: "B", GUID: "2"}, {Title: "C", GUID: "3"}}},
+	}
+
+	mc := NewMergeConverter("", "", "", 2)
+	merged := mc.Convert(&feeds)
+
+	if len(merged[0].Items) != 2 {
+		t.Fatalf("expected maxItems to cap at 2, got %d", len(merged[0].Items))
+	}
+}
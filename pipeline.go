@@ -0,0 +1,246 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// ConfigLoader class implements Loader. It fetches every source
+// declared in a PipelineConfig, applying each source's own headers and
+// auth, over the same bounded worker pool and exponential-backoff retry
+// that URLLoader uses for -url, so -config fetches get equally
+// resilient to transient 5xx/network errors.
+type ConfigLoader struct {
+	sources     []SourceConfig
+	concurrency int
+	client      *http.Client
+}
+
+func NewConfigLoader(sources []SourceConfig, concurrency int, client *http.Client) Loader {
+	return &ConfigLoader{
+		sources:     sources,
+		concurrency: concurrency,
+		client:      client,
+	}
+}
+
+func (c *ConfigLoader) Load(ctx context.Context) ([]string, error) {
+	bodies := fetchConcurrently(ctx, c.concurrency, len(c.sources),
+		func(ctx context.Context, i int) (string, error) {
+			return c.fetchWithRetry(ctx, c.sources[i])
+		},
+		func(i int, err error) {
+			fmt.Fprintf(os.Stderr, "Failed to fetch %s: %s\n", c.sources[i].URL, err.Error())
+			setExitCode(1)
+		},
+	)
+	return bodies, ctx.Err()
+}
+
+func (c *ConfigLoader) fetchWithRetry(ctx context.Context, src SourceConfig) (string, error) {
+	return fetchWithBackoff(ctx, func(ctx context.Context) (string, int, error) {
+		return c.fetchOnce(ctx, src)
+	})
+}
+
+func (c *ConfigLoader) fetchOnce(ctx context.Context, src SourceConfig) (string, int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, src.URL, nil)
+	if err != nil {
+		return "", 0, err
+	}
+	for k, v := range src.Headers {
+		req.Header.Set(k, v)
+	}
+	applyAuth(req, src.Auth)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", 0, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", resp.StatusCode, err
+	}
+	return string(body), resp.StatusCode, nil
+}
+
+func applyAuth(req *http.Request, auth *AuthConfig) {
+	if auth == nil {
+		return
+	}
+	switch auth.Type {
+	case "basic":
+		req.SetBasicAuth(auth.Username, auth.Password)
+	case "bearer":
+		req.Header.Set("Authorization", "Bearer "+auth.Token)
+	}
+}
+
+// HTTPPrinter class implements Printer. It POSTs (or PUTs, etc) the
+// converted feed body to a webhook URL.
+type HTTPPrinter struct {
+	url     string
+	method  string
+	headers map[string]string
+	client  *http.Client
+}
+
+func NewHTTPPrinter(url string, method string, headers map[string]string) Printer {
+	if method == "" {
+		method = http.MethodPost
+	}
+	return &HTTPPrinter{
+		url:     url,
+		method:  method,
+		headers: headers,
+		client:  &http.Client{},
+	}
+}
+
+func (h *HTTPPrinter) Print(rss *[]string) {
+	for _, r := range *rss {
+		req, err := http.NewRequest(h.method, h.url, strings.NewReader(r))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to build request for %s: %s\n", h.url, err.Error())
+			exitCode = 6
+			continue
+		}
+		for k, v := range h.headers {
+			req.Header.Set(k, v)
+		}
+
+		resp, err := h.client.Do(req)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to POST to %s: %s\n", h.url, err.Error())
+			exitCode = 6
+			continue
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode >= 400 {
+			fmt.Fprintf(os.Stderr, "%s returned %d\n", h.url, resp.StatusCode)
+			exitCode = 6
+		}
+	}
+}
+
+// MultiPrinter class implements Printer. It fans the converted feed
+// out to every configured sink.
+type MultiPrinter struct {
+	printers []Printer
+}
+
+func NewMultiPrinter(printers ...Printer) Printer {
+	return &MultiPrinter{printers: printers}
+}
+
+func (m *MultiPrinter) Print(rss *[]string) {
+	for _, p := range m.printers {
+		p.Print(rss)
+	}
+}
+
+// BuildConverter turns an ordered list of rules into a single
+// Converter, looking each rule's type up in a small factory.
+func BuildConverter(rules []RuleConfig) (Converter, error) {
+	converters := make([]Converter, 0, len(rules))
+	for _, rule := range rules {
+		converter, err := newRuleConverter(rule)
+		if err != nil {
+			return nil, err
+		}
+		converters = append(converters, converter)
+	}
+	return NewCompositeConverter(converters...), nil
+}
+
+func newRuleConverter(rule RuleConfig) (Converter, error) {
+	switch rule.Type {
+	case "replace":
+		return NewFieldReplaceConverter(FeedField(rule.Field), rule.Search, rule.Replace), nil
+	case "strip-html":
+		return NewStripHTMLConverter(FeedField(rule.Field)), nil
+	case "prefix-title":
+		return NewPrefixTitleConverter(rule.Prefix), nil
+	case "rewrite-link-domain":
+		return NewRewriteLinkDomainConverter(rule.FromDomain, rule.ToDomain), nil
+	case "regex-replace":
+		return NewRegexConverter(FeedField(rule.Field), rule.Search, rule.Replace)
+	case "xpath":
+		return NewXPathConverter(rule.Path, rule.Replace, rule.Attr, rule.Delete)
+	default:
+		return nil, fmt.Errorf("unknown rule type %q", rule.Type)
+	}
+}
+
+// BuildPrinter turns a list of sinks into a single Printer. An empty
+// list falls back to stdout, matching the plain-CLI default.
+func BuildPrinter(sinks []SinkConfig) (Printer, error) {
+	if len(sinks) == 0 {
+		return NewOutputPrinter(), nil
+	}
+
+	printers := make([]Printer, 0, len(sinks))
+	for _, sink := range sinks {
+		printer, err := newSinkPrinter(sink)
+		if err != nil {
+			return nil, err
+		}
+		printers = append(printers, printer)
+	}
+	if len(printers) == 1 {
+		return printers[0], nil
+	}
+	return NewMultiPrinter(printers...), nil
+}
+
+func newSinkPrinter(sink SinkConfig) (Printer, error) {
+	switch sink.Type {
+	case "stdout":
+		return NewOutputPrinter(), nil
+	case "file":
+		return NewFileOutputPrinter(sink.Path), nil
+	case "http":
+		return NewHTTPPrinter(sink.URL, sink.Method, sink.Headers), nil
+	case "dir":
+		return NewDirPrinter(sink.Path), nil
+	default:
+		return nil, fmt.Errorf("unknown sink type %q", sink.Type)
+	}
+}
+
+// RunConfigPipeline loads a PipelineConfig and assembles an RSSDocument
+// from its declared sources, rules and sinks.
+func RunConfigPipeline(cfgPath string, concurrency int, timeout time.Duration) (*RSSDocument, error) {
+	cfg, err := LoadPipelineConfig(cfgPath)
+	if err != nil {
+		return nil, err
+	}
+
+	converter, err := BuildConverter(cfg.Rules)
+	if err != nil {
+		return nil, err
+	}
+	printer, err := BuildPrinter(cfg.Sinks)
+	if err != nil {
+		return nil, err
+	}
+
+	loader := NewConfigLoader(cfg.Sources, concurrency, &http.Client{Timeout: timeout})
+
+	return &RSSDocument{
+		rawrss:     make([]string, 0),
+		loader:     loader,
+		parser:     NewFeedParser(),
+		converter:  converter,
+		serializer: NewFeedSerializer(),
+		printer:    printer,
+	}, nil
+}
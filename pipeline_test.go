@@ -0,0 +1,77 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHTTPPrinterPostsEachBody(t *testing.T) {
+	var gotBodies []string
+	var gotMethod string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotMethod = r.Method
+		buf := make([]byte, r.ContentLength)
+		r.Body.Read(buf)
+		gotBodies = append(gotBodies, string(buf))
+	}))
+	defer srv.Close()
+
+	printer := NewHTTPPrinter(srv.URL, "", nil)
+	bodies := []string{"<rss>one</rss>", "<rss>two</rss>"}
+	printer.Print(&bodies)
+
+	if gotMethod != http.MethodPost {
+		t.Fatalf("method = %q, want POST (the default)", gotMethod)
+	}
+	if len(gotBodies) != 2 || gotBodies[0] != bodies[0] || gotBodies[1] != bodies[1] {
+		t.Fatalf("gotBodies = %v, want %v", gotBodies, bodies)
+	}
+}
+
+func TestHTTPPrinterSetsHeaders(t *testing.T) {
+	var gotHeader string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotHeader = r.Header.Get("X-Custom")
+	}))
+	defer srv.Close()
+
+	printer := NewHTTPPrinter(srv.URL, http.MethodPut, map[string]string{"X-Custom": "value"})
+	bodies := []string{"<rss/>"}
+	printer.Print(&bodies)
+
+	if gotHeader != "value" {
+		t.Fatalf("X-Custom header = %q, want %q", gotHeader, "value")
+	}
+}
+
+func TestApplyAuthBasic(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodGet, "http://x", nil)
+	applyAuth(req, &AuthConfig{Type: "basic", Username: "u", Password: "p"})
+
+	user, pass, ok := req.BasicAuth()
+	if !ok || user != "u" || pass != "p" {
+		t.Fatalf("BasicAuth() = (%q, %q, %v), want (u, p, true)", user, pass, ok)
+	}
+}
+
+func TestApplyAuthBearer(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodGet, "http://x", nil)
+	applyAuth(req, &AuthConfig{Type: "bearer", Token: "tok"})
+
+	if got := req.Header.Get("Authorization"); got != "Bearer tok" {
+		t.Fatalf("Authorization header = %q, want %q", got, "Bearer tok")
+	}
+}
+
+func TestNewRuleConverterUnknownType(t *testing.T) {
+	if _, err := newRuleConverter(RuleConfig{Type: "not-a-real-rule"}); err == nil {
+		t.Fatal("expected an error for an unknown rule type")
+	}
+}
+
+func TestNewSinkPrinterUnknownType(t *testing.T) {
+	if _, err := newSinkPrinter(SinkConfig{Type: "not-a-real-sink"}); err == nil {
+		t.Fatal("expected an error for an unknown sink type")
+	}
+}
@@ -0,0 +1,63 @@
+package main
+
+import "regexp"
+
+// RegexConverter class implements Converter. Like FieldReplaceConverter
+// but the search side is a regexp, and replace may reference capture
+// groups ($1, $2, ...).
+type RegexConverter struct {
+	field   FeedField
+	search  *regexp.Regexp
+	replace string
+}
+
+func (rc *RegexConverter) Convert(feeds *[]*Feed) []*Feed {
+	for _, feed := range *feeds {
+		rc.replaceChannel(feed)
+		for _, item := range feed.Items {
+			rc.replaceItem(item)
+		}
+	}
+
+	return *feeds
+}
+
+func (rc *RegexConverter) replaceChannel(feed *Feed) {
+	switch rc.field {
+	case FieldTitle:
+		feed.Title = rc.search.ReplaceAllString(feed.Title, rc.replace)
+	case FieldDescription:
+		feed.Description = rc.search.ReplaceAllString(feed.Description, rc.replace)
+	case FieldLink:
+		feed.Link = rc.search.ReplaceAllString(feed.Link, rc.replace)
+	}
+}
+
+func (rc *RegexConverter) replaceItem(item *Item) {
+	switch rc.field {
+	case FieldTitle:
+		item.Title = rc.search.ReplaceAllString(item.Title, rc.replace)
+	case FieldDescription:
+		item.Description = rc.search.ReplaceAllString(item.Description, rc.replace)
+	case FieldLink:
+		item.Link = rc.search.ReplaceAllString(item.Link, rc.replace)
+	case FieldContent:
+		item.Content = rc.search.ReplaceAllString(item.Content, rc.replace)
+	case FieldAuthor:
+		item.Author = rc.search.ReplaceAllString(item.Author, rc.replace)
+	}
+}
+
+// NewRegexConverter compiles pattern and returns a Converter that
+// applies it to the targeted field, or an error if pattern is invalid.
+func NewRegexConverter(field FeedField, pattern string, replace string) (Converter, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	return &RegexConverter{
+		field:   field,
+		search:  re,
+		replace: replace,
+	}, nil
+}
@@ -0,0 +1,31 @@
+package main
+
+import "testing"
+
+func TestRegexConverter(t *testing.T) {
+	conv, err := NewRegexConverter(FieldTitle, `(\w+) (\w+)`, "$2 $1")
+	if err != nil {
+		t.Fatalf("NewRegexConverter: %s", err)
+	}
+
+	feeds := []*Feed{
+		{
+			Title: "Hello World",
+			Items: []*Item{{Title: "Foo Bar"}},
+		},
+	}
+	result := conv.Convert(&feeds)
+
+	if got := result[0].Title; got != "World Hello" {
+		t.Fatalf("channel title = %q, want %q", got, "World Hello")
+	}
+	if got := result[0].Items[0].Title; got != "Bar Foo" {
+		t.Fatalf("item title = %q, want %q", got, "Bar Foo")
+	}
+}
+
+func TestNewRegexConverterRejectsInvalidPattern(t *testing.T) {
+	if _, err := NewRegexConverter(FieldTitle, "(unterminated", ""); err == nil {
+		t.Fatal("expected an error for an invalid regexp pattern")
+	}
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+)
+
+var htmlTagPattern = regexp.MustCompile(`<[^>]*>`)
+
+// StripHTMLConverter class implements Converter. It strips HTML tags
+// out of the targeted field.
+type StripHTMLConverter struct {
+	field FeedField
+}
+
+func (s *StripHTMLConverter) Convert(feeds *[]*Feed) []*Feed {
+	for _, feed := range *feeds {
+		s.stripChannel(feed)
+		for _, item := range feed.Items {
+			s.stripItem(item)
+		}
+	}
+
+	return *feeds
+}
+
+func (s *StripHTMLConverter) stripChannel(feed *Feed) {
+	switch s.field {
+	case FieldTitle:
+		feed.Title = htmlTagPattern.ReplaceAllString(feed.Title, "")
+	case FieldDescription:
+		feed.Description = htmlTagPattern.ReplaceAllString(feed.Description, "")
+	}
+}
+
+func (s *StripHTMLConverter) stripItem(item *Item) {
+	switch s.field {
+	case FieldTitle:
+		item.Title = htmlTagPattern.ReplaceAllString(item.Title, "")
+	case FieldDescription:
+		item.Description = htmlTagPattern.ReplaceAllString(item.Description, "")
+	case FieldContent:
+		item.Content = htmlTagPattern.ReplaceAllString(item.Content, "")
+	}
+}
+
+func NewStripHTMLConverter(field FeedField) Converter {
+	return &StripHTMLConverter{field: field}
+}
+
+// PrefixTitleConverter class implements Converter. It prepends a fixed
+// prefix to the channel title and every item title.
+type PrefixTitleConverter struct {
+	prefix string
+}
+
+func (p *PrefixTitleConverter) Convert(feeds *[]*Feed) []*Feed {
+	for _, feed := range *feeds {
+		feed.Title = p.prefix + feed.Title
+		for _, item := range feed.Items {
+			item.Title = p.prefix + item.Title
+		}
+	}
+
+	return *feeds
+}
+
+func NewPrefixTitleConverter(prefix string) Converter {
+	return &PrefixTitleConverter{prefix: prefix}
+}
+
+// RewriteLinkDomainConverter class implements Converter. It replaces
+// one domain with another wherever it appears in a link field.
+type RewriteLinkDomainConverter struct {
+	fromDomain string
+	toDomain   string
+}
+
+func (r *RewriteLinkDomainConverter) Convert(feeds *[]*Feed) []*Feed {
+	for _, feed := range *feeds {
+		feed.Link = strings.Replace(feed.Link, r.fromDomain, r.toDomain, -1)
+		for _, item := range feed.Items {
+			item.Link = strings.Replace(item.Link, r.fromDomain, r.toDomain, -1)
+		}
+	}
+
+	return *feeds
+}
+
+func NewRewriteLinkDomainConverter(fromDomain string, toDomain string) Converter {
+	return &RewriteLinkDomainConverter{
+		fromDomain: fromDomain,
+		toDomain:   toDomain,
+	}
+}
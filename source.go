@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// FileLoader class implements Loader. It reads feeds from local paths,
+// expanding each entry as a glob (a plain path with no wildcard simply
+// matches itself).
+type FileLoader struct {
+	patterns []string
+}
+
+func NewFileLoader(patterns []string) Loader {
+	return &FileLoader{patterns: patterns}
+}
+
+func (f *FileLoader) Load(ctx context.Context) ([]string, error) {
+	var bodies []string
+	for _, pattern := range f.patterns {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to expand -file pattern %s: %s\n", pattern, err.Error())
+			exitCode = 7
+			continue
+		}
+		if len(matches) == 0 {
+			matches = []string{pattern}
+		}
+		for _, path := range matches {
+			data, err := ioutil.ReadFile(path)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to read %s: %s\n", path, err.Error())
+				exitCode = 7
+				continue
+			}
+			bodies = append(bodies, string(data))
+		}
+	}
+	return bodies, ctx.Err()
+}
+
+// StdinLoader class implements Loader. It reads a single feed document
+// from standard input, for piping ("-url=-").
+type StdinLoader struct{}
+
+func NewStdinLoader() Loader {
+	return &StdinLoader{}
+}
+
+func (s *StdinLoader) Load(ctx context.Context) ([]string, error) {
+	data, err := ioutil.ReadAll(os.Stdin)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to read stdin: %s\n", err.Error())
+		exitCode = 7
+		return nil, err
+	}
+	return []string{string(data)}, nil
+}
+
+// MultiLoader class implements Loader. It runs a list of Loaders in
+// turn and concatenates their results, so a single run can mix HTTP
+// URLs, local files and stdin.
+type MultiLoader struct {
+	loaders []Loader
+}
+
+func NewMultiLoader(loaders ...Loader) Loader {
+	return &MultiLoader{loaders: loaders}
+}
+
+func (m *MultiLoader) Load(ctx context.Context) ([]string, error) {
+	var all []string
+	for _, loader := range m.loaders {
+		bodies, err := loader.Load(ctx)
+		all = append(all, bodies...)
+		if ctx.Err() != nil {
+			return all, err
+		}
+	}
+	return all, nil
+}
+
+// BuildLoader picks a Loader (or a MultiLoader combining several) based
+// on each entry's prefix: http:// and https:// go through the
+// concurrent URLLoader, file:// and plain -file patterns go through
+// FileLoader, and "-" reads stdin.
+func BuildLoader(urls []string, files []string, concurrency int, timeout time.Duration) Loader {
+	var httpURLs []string
+	var filePatterns []string
+	var loaders []Loader
+
+	for _, u := range urls {
+		switch {
+		case u == "-":
+			loaders = append(loaders, NewStdinLoader())
+		case strings.HasPrefix(u, "file://"):
+			filePatterns = append(filePatterns, strings.TrimPrefix(u, "file://"))
+		default:
+			httpURLs = append(httpURLs, u)
+		}
+	}
+	filePatterns = append(filePatterns, files...)
+
+	if len(httpURLs) > 0 {
+		loaders = append(loaders, NewUrlLoader(&httpURLs, concurrency, timeout))
+	}
+	if len(filePatterns) > 0 {
+		loaders = append(loaders, NewFileLoader(filePatterns))
+	}
+
+	return NewMultiLoader(loaders...)
+}
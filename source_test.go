@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFileLoaderReadsGlob(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"a.xml", "b.xml"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(name), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	loader := NewFileLoader([]string{filepath.Join(dir, "*.xml")})
+	bodies, err := loader.Load(context.Background())
+	if err != nil {
+		t.Fatalf("Load: %s", err)
+	}
+	if len(bodies) != 2 {
+		t.Fatalf("got %d bodies, want 2: %v", len(bodies), bodies)
+	}
+}
+
+func TestFileLoaderSkipsUnreadableFiles(t *testing.T) {
+	dir := t.TempDir()
+	good := filepath.Join(dir, "good.xml")
+	if err := os.WriteFile(good, []byte("good"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	loader := NewFileLoader([]string{filepath.Join(dir, "missing.xml"), good})
+	bodies, err := loader.Load(context.Background())
+	if err != nil {
+		t.Fatalf("Load: %s", err)
+	}
+	if len(bodies) != 1 || bodies[0] != "good" {
+		t.Fatalf("bodies = %v, want only the readable file", bodies)
+	}
+}
+
+func TestStdinLoader(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	origStdin := os.Stdin
+	os.Stdin = r
+	defer func() { os.Stdin = origStdin }()
+
+	go func() {
+		w.Write([]byte("feed-from-stdin"))
+		w.Close()
+	}()
+
+	bodies, err := NewStdinLoader().Load(context.Background())
+	if err != nil {
+		t.Fatalf("Load: %s", err)
+	}
+	if len(bodies) != 1 || bodies[0] != "feed-from-stdin" {
+		t.Fatalf("bodies = %v, want [feed-from-stdin]", bodies)
+	}
+}
+
+type fakeLoader struct {
+	bodies []string
+}
+
+func (f *fakeLoader) Load(ctx context.Context) ([]string, error) {
+	return f.bodies, nil
+}
+
+func TestMultiLoaderConcatenates(t *testing.T) {
+	loader := NewMultiLoader(
+		&fakeLoader{bodies: []string{"a"}},
+		&fakeLoader{bodies: []string{"b", "c"}},
+	)
+	bodies, err := loader.Load(context.Background())
+	if err != nil {
+		t.Fatalf("Load: %s", err)
+	}
+	want := []string{"a", "b", "c"}
+	if len(bodies) != len(want) {
+		t.Fatalf("bodies = %v, want %v", bodies, want)
+	}
+	for i := range want {
+		if bodies[i] != want[i] {
+			t.Fatalf("bodies = %v, want %v", bodies, want)
+		}
+	}
+}
+
+func TestBuildLoaderDispatchesByPrefix(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "feed.xml")
+	if err := os.WriteFile(path, []byte("file-feed"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	loader := BuildLoader([]string{"file://" + path}, nil, 2, time.Second)
+	bodies, err := loader.Load(context.Background())
+	if err != nil {
+		t.Fatalf("Load: %s", err)
+	}
+	if len(bodies) != 1 || bodies[0] != "file-feed" {
+		t.Fatalf("bodies = %v, want [file-feed]", bodies)
+	}
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestURLLoader(urls []string) *URLLoader {
+	return &URLLoader{
+		urls:        &urls,
+		concurrency: 2,
+		client:      &http.Client{Timeout: 2 * time.Second},
+	}
+}
+
+func TestFetchWithRetrySucceedsAfterTransientErrors(t *testing.T) {
+	var attempts int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	u := newTestURLLoader([]string{srv.URL})
+	body, err := u.fetchWithRetry(context.Background(), srv.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if body != "ok" {
+		t.Fatalf("body = %q, want %q", body, "ok")
+	}
+	if attempts != 3 {
+		t.Fatalf("attempts = %d, want 3", attempts)
+	}
+}
+
+func TestFetchWithRetryExhaustsAndFails(t *testing.T) {
+	var attempts int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	u := newTestURLLoader([]string{srv.URL})
+	u.client.Timeout = 1 * time.Second
+	start := time.Now()
+	_, err := u.fetchWithRetry(context.Background(), srv.URL)
+	elapsed := time.Since(start)
+
+	if err == nil {
+		t.Fatal("expected an error once retries are exhausted")
+	}
+	if attempts != maxFetchRetries+1 {
+		t.Fatalf("attempts = %d, want %d", attempts, maxFetchRetries+1)
+	}
+	// backoff is 500ms, 1s, 2s between the 4 attempts -- make sure we
+	// actually waited rather than retrying immediately.
+	if elapsed < 1500*time.Millisecond {
+		t.Fatalf("elapsed = %s, expected backoff to take at least 1.5s", elapsed)
+	}
+}
+
+func TestURLLoaderLoadDropsFailedFetches(t *testing.T) {
+	bad := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer bad.Close()
+	good := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("feed-body"))
+	}))
+	defer good.Close()
+
+	u := &URLLoader{
+		urls:        &[]string{bad.URL, good.URL},
+		concurrency: 2,
+		client:      &http.Client{Timeout: 1 * time.Second},
+	}
+
+	bodies, err := u.Load(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(bodies) != 1 || bodies[0] != "feed-body" {
+		t.Fatalf("bodies = %v, want only the successful fetch", bodies)
+	}
+}
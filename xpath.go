@@ -0,0 +1,86 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/antchfx/xmlquery"
+	"github.com/antchfx/xpath"
+)
+
+// XPathConverter class implements Converter. It re-serializes each Feed
+// to its wire format, applies an XPath edit against the resulting DOM
+// with xmlquery, then re-parses the result back into a Feed. This lets
+// XPathConverter reach nodes and attributes that don't have a place in
+// the normalized Feed/Item model.
+type XPathConverter struct {
+	path    string
+	replace string
+	attr    string
+	delete  bool
+}
+
+func (xc *XPathConverter) Convert(feeds *[]*Feed) []*Feed {
+	serializer := NewFeedSerializer()
+	parser := NewFeedParser()
+
+	result := make([]*Feed, 0, len(*feeds))
+	for _, feed := range *feeds {
+		edited, err := xc.apply(feed, serializer, parser)
+		if err != nil {
+			result = append(result, feed)
+			continue
+		}
+		result = append(result, edited)
+	}
+	return result
+}
+
+func (xc *XPathConverter) apply(feed *Feed, serializer FeedSerializer, parser FeedParser) (*Feed, error) {
+	raw, err := serializer.Serialize(feed)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := xmlquery.Parse(strings.NewReader(raw))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, node := range xmlquery.Find(doc, xc.path) {
+		switch {
+		case xc.delete:
+			xmlquery.RemoveFromTree(node)
+		case xc.attr != "":
+			node.SetAttr(xc.attr, xc.replace)
+		default:
+			setNodeText(node, xc.replace)
+		}
+	}
+
+	return parser.Parse(doc.OutputXML(false))
+}
+
+// setNodeText replaces a node's text content, adding a text child if it
+// didn't already have one.
+func setNodeText(node *xmlquery.Node, text string) {
+	if node.FirstChild != nil && node.FirstChild.Type == xmlquery.TextNode {
+		node.FirstChild.Data = text
+		return
+	}
+	xmlquery.AddChild(node, &xmlquery.Node{Type: xmlquery.TextNode, Data: text})
+}
+
+// NewXPathConverter validates path up front and returns a Converter
+// that replaces matching nodes' text (or, if attr is set, an attribute;
+// or, if deleteMatch is true, removes the matching nodes entirely).
+func NewXPathConverter(path string, replace string, attr string, deleteMatch bool) (Converter, error) {
+	if _, err := xpath.Compile(path); err != nil {
+		return nil, err
+	}
+	return &XPathConverter{
+		path:    path,
+		replace: replace,
+		attr:    attr,
+		delete:  deleteMatch,
+	}, nil
+}
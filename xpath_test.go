@@ -0,0 +1,86 @@
+package main
+
+import (
+	"encoding/xml"
+	"testing"
+)
+
+func sampleRSSFeed() *Feed {
+	return &Feed{
+		Format:      FormatRSS,
+		Title:       "T",
+		Link:        "http://x",
+		Description: "D",
+		Items: []*Item{
+			{
+				Title:       "Hello",
+				Link:        "http://x/1",
+				Description: "desc",
+				Extra: []RawXML{
+					{
+						XMLName: xml.Name{Local: "enclosure"},
+						Attrs:   []xml.Attr{{Name: xml.Name{Local: "url"}, Value: "http://x/a.mp3"}},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestXPathConverterRewritesText(t *testing.T) {
+	conv, err := NewXPathConverter("//item/title", "Goodbye", "", false)
+	if err != nil {
+		t.Fatalf("NewXPathConverter: %s", err)
+	}
+
+	feeds := []*Feed{sampleRSSFeed()}
+	result := conv.Convert(&feeds)
+
+	if got := result[0].Items[0].Title; got != "Goodbye" {
+		t.Fatalf("title = %q, want %q", got, "Goodbye")
+	}
+}
+
+func TestXPathConverterRewritesAttr(t *testing.T) {
+	conv, err := NewXPathConverter("//item/enclosure", "http://x/b.mp3", "url", false)
+	if err != nil {
+		t.Fatalf("NewXPathConverter: %s", err)
+	}
+
+	feeds := []*Feed{sampleRSSFeed()}
+	result := conv.Convert(&feeds)
+
+	var url string
+	for _, extra := range result[0].Items[0].Extra {
+		if extra.XMLName.Local == "enclosure" {
+			for _, a := range extra.Attrs {
+				if a.Name.Local == "url" {
+					url = a.Value
+				}
+			}
+		}
+	}
+	if url != "http://x/b.mp3" {
+		t.Fatalf("enclosure url = %q, want %q", url, "http://x/b.mp3")
+	}
+}
+
+func TestXPathConverterDeletesNodes(t *testing.T) {
+	conv, err := NewXPathConverter("//item/description", "", "", true)
+	if err != nil {
+		t.Fatalf("NewXPathConverter: %s", err)
+	}
+
+	feeds := []*Feed{sampleRSSFeed()}
+	result := conv.Convert(&feeds)
+
+	if got := result[0].Items[0].Description; got != "" {
+		t.Fatalf("description = %q, want empty after delete", got)
+	}
+}
+
+func TestNewXPathConverterRejectsInvalidPath(t *testing.T) {
+	if _, err := NewXPathConverter("///not valid xpath[[", "", "", false); err == nil {
+		t.Fatal("expected an error for an invalid xpath expression")
+	}
+}